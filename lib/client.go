@@ -1,11 +1,14 @@
 package samplify
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -17,6 +20,22 @@ const (
 type ClientOptions struct {
 	APIBaseURL string
 	AuthURL    string
+
+	// HTTPClient is used to make all requests to the Samplify API. If nil,
+	// http.DefaultClient is used. Set this to customize proxying, TLS, or tracing.
+	HTTPClient *http.Client
+
+	// RetryPolicy controls retry/backoff behavior for failed requests. If nil,
+	// DefaultRetryPolicy() is used.
+	RetryPolicy *RetryPolicy
+
+	// TokenSource, if set, is used to acquire and refresh access tokens instead of
+	// the default password-grant flow driven by Credentials.
+	TokenSource TokenSource
+
+	// Logger receives structured logs for auth acquisition, retries, non-2xx
+	// responses, and feasibility polling. If nil, logging is disabled.
+	Logger Logger
 }
 
 // Client is used to make API requests to the Samplify API.
@@ -24,75 +43,123 @@ type Client struct {
 	Credentials TokenRequest
 	Auth        TokenResponse
 	Options     ClientOptions
+
+	authMu    sync.RWMutex
+	authGroup singleflight.Group
 }
 
 // CreateProject ...
 func (c *Client) CreateProject(project *CreateUpdateProjectCriteria) (*ProjectResponse, error) {
+	return c.CreateProjectWithContext(context.Background(), project)
+}
+
+// CreateProjectWithContext ...
+func (c *Client) CreateProjectWithContext(ctx context.Context, project *CreateUpdateProjectCriteria) (*ProjectResponse, error) {
 	res := &ProjectResponse{}
-	err := c.requestAndParseResponse("POST", "/projects", project, res)
+	err := c.requestAndParseResponse(ctx, "POST", "/projects", project, res)
 	return res, err
 }
 
 // UpdateProject ...
 func (c *Client) UpdateProject(project *CreateUpdateProjectCriteria) (*ProjectResponse, error) {
+	return c.UpdateProjectWithContext(context.Background(), project)
+}
+
+// UpdateProjectWithContext ...
+func (c *Client) UpdateProjectWithContext(ctx context.Context, project *CreateUpdateProjectCriteria) (*ProjectResponse, error) {
 	res := &ProjectResponse{}
 	path := fmt.Sprintf("/projects/%s", project.ExtProjectID)
-	err := c.requestAndParseResponse("POST", path, project, res)
+	err := c.requestAndParseResponse(ctx, "POST", path, project, res)
 	return res, err
 }
 
 // BuyProject ...
 func (c *Client) BuyProject(extProjectID string, buy []*BuyProjectCriteria) (*BuyProjectResponse, error) {
+	return c.BuyProjectWithContext(context.Background(), extProjectID, buy)
+}
+
+// BuyProjectWithContext ...
+func (c *Client) BuyProjectWithContext(ctx context.Context, extProjectID string, buy []*BuyProjectCriteria) (*BuyProjectResponse, error) {
 	res := &BuyProjectResponse{}
 	path := fmt.Sprintf("/projects/%s/buy", extProjectID)
-	err := c.requestAndParseResponse("POST", path, buy, res)
+	err := c.requestAndParseResponse(ctx, "POST", path, buy, res)
 	return res, err
 }
 
 // CloseProject ...
 func (c *Client) CloseProject(extProjectID string) (*CloseProjectResponse, error) {
+	return c.CloseProjectWithContext(context.Background(), extProjectID)
+}
+
+// CloseProjectWithContext ...
+func (c *Client) CloseProjectWithContext(ctx context.Context, extProjectID string) (*CloseProjectResponse, error) {
 	res := &CloseProjectResponse{}
 	path := fmt.Sprintf("/projects/%s/close", extProjectID)
-	err := c.requestAndParseResponse("POST", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "POST", path, nil, res)
 	return res, err
 }
 
 // GetAllProjects ...
 func (c *Client) GetAllProjects() (*GetAllProjectsResponse, error) {
+	return c.GetAllProjectsWithContext(context.Background())
+}
+
+// GetAllProjectsWithContext ...
+func (c *Client) GetAllProjectsWithContext(ctx context.Context) (*GetAllProjectsResponse, error) {
 	res := &GetAllProjectsResponse{}
-	err := c.requestAndParseResponse("GET", "/projects", nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", "/projects", nil, res)
 	return res, err
 }
 
 // GetProjectBy returns project by id
 func (c *Client) GetProjectBy(extProjectID string) (*ProjectResponse, error) {
+	return c.GetProjectByWithContext(context.Background(), extProjectID)
+}
+
+// GetProjectByWithContext returns project by id
+func (c *Client) GetProjectByWithContext(ctx context.Context, extProjectID string) (*ProjectResponse, error) {
 	res := &ProjectResponse{}
 	path := fmt.Sprintf("/projects/%v", extProjectID)
-	err := c.requestAndParseResponse("GET", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", path, nil, res)
 	return res, err
 }
 
 // GetProjectReport returns a project's report based on observed data from actual panelists.
 func (c *Client) GetProjectReport(extProjectID string) (*ProjectReportResponse, error) {
+	return c.GetProjectReportWithContext(context.Background(), extProjectID)
+}
+
+// GetProjectReportWithContext returns a project's report based on observed data from actual panelists.
+func (c *Client) GetProjectReportWithContext(ctx context.Context, extProjectID string) (*ProjectReportResponse, error) {
 	res := &ProjectReportResponse{}
 	path := fmt.Sprintf("/projects/%v/report", extProjectID)
-	err := c.requestAndParseResponse("GET", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", path, nil, res)
 	return res, err
 }
 
 // AddLineItem ...
 func (c *Client) AddLineItem(extProjectID string, lineItem *LineItem) (*LineItemResponse, error) {
+	return c.AddLineItemWithContext(context.Background(), extProjectID, lineItem)
+}
+
+// AddLineItemWithContext ...
+func (c *Client) AddLineItemWithContext(ctx context.Context, extProjectID string, lineItem *LineItem) (*LineItemResponse, error) {
 	res := &LineItemResponse{}
 	path := fmt.Sprintf("/projects/%s/lineItems", extProjectID)
-	err := c.requestAndParseResponse("POST", path, lineItem, res)
+	err := c.requestAndParseResponse(ctx, "POST", path, lineItem, res)
 	return res, err
 }
 
 // UpdateLineItem ...
 func (c *Client) UpdateLineItem(extProjectID, extLineItemID string, lineItem *LineItem) (*LineItemResponse, error) {
+	return c.UpdateLineItemWithContext(context.Background(), extProjectID, extLineItemID, lineItem)
+}
+
+// UpdateLineItemWithContext ...
+func (c *Client) UpdateLineItemWithContext(ctx context.Context, extProjectID, extLineItemID string, lineItem *LineItem) (*LineItemResponse, error) {
 	res := &LineItemResponse{}
 	path := fmt.Sprintf("/projects/%s/lineItems/%s", extProjectID, extLineItemID)
-	err := c.requestAndParseResponse("POST", path, lineItem, res)
+	err := c.requestAndParseResponse(ctx, "POST", path, lineItem, res)
 	return res, err
 }
 
@@ -100,72 +167,137 @@ func (c *Client) UpdateLineItem(extProjectID, extLineItemID string, lineItem *Li
 func (c *Client) ChangeLineItemState(extProjectID, extLineItemID string, action Action) (
 	*ChangeLineItemStateResponse, error) {
 
+	return c.ChangeLineItemStateWithContext(context.Background(), extProjectID, extLineItemID, action)
+}
+
+// ChangeLineItemStateWithContext ... Changes the state of the line item based on provided action.
+func (c *Client) ChangeLineItemStateWithContext(ctx context.Context, extProjectID, extLineItemID string, action Action) (
+	*ChangeLineItemStateResponse, error) {
+
 	res := &ChangeLineItemStateResponse{}
 	path := fmt.Sprintf("/projects/%s/lineItems/%s/%s", extProjectID, extLineItemID, action)
-	err := c.requestAndParseResponse("POST", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "POST", path, nil, res)
 	return res, err
 }
 
 // GetAllLineItems ...
 func (c *Client) GetAllLineItems(extProjectID string) (*GetAllLineItemsResponse, error) {
+	return c.GetAllLineItemsWithContext(context.Background(), extProjectID)
+}
+
+// GetAllLineItemsWithContext ...
+func (c *Client) GetAllLineItemsWithContext(ctx context.Context, extProjectID string) (*GetAllLineItemsResponse, error) {
 	res := &GetAllLineItemsResponse{}
 	path := fmt.Sprintf("/projects/%s/lineItems", extProjectID)
-	err := c.requestAndParseResponse("GET", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", path, nil, res)
 	return res, err
 }
 
 // GetLineItemBy ...
 func (c *Client) GetLineItemBy(extProjectID, extLineItemID string) (*LineItemResponse, error) {
+	return c.GetLineItemByWithContext(context.Background(), extProjectID, extLineItemID)
+}
+
+// GetLineItemByWithContext ...
+func (c *Client) GetLineItemByWithContext(ctx context.Context, extProjectID, extLineItemID string) (*LineItemResponse, error) {
 	res := &LineItemResponse{}
 	path := fmt.Sprintf("/projects/%s/lineItems/%s", extProjectID, extLineItemID)
-	err := c.requestAndParseResponse("GET", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", path, nil, res)
 	return res, err
 }
 
 // GetFeasibility ... Returns the feasibility for all the line items of the requested project. Takes 20 - 120
 // seconds to execute. Check the `GetFeasibilityResponse.Feasibility.Status` field value to see if it is
 // FeasibilityStatusReady ("READY") or FeasibilityStatusProcessing ("PROCESSING")
-// If GetFeasibilityResponse.Feasibility.Status == FeasibilityStatusProcessing, call this function again in 2 mins.
+// If GetFeasibilityResponse.Feasibility.Status == FeasibilityStatusProcessing, call this function again in 2 mins,
+// or use WaitForFeasibility to poll until it is ready.
 func (c *Client) GetFeasibility(extProjectID string) (*GetFeasibilityResponse, error) {
+	return c.GetFeasibilityWithContext(context.Background(), extProjectID)
+}
+
+// GetFeasibilityWithContext ... See GetFeasibility.
+func (c *Client) GetFeasibilityWithContext(ctx context.Context, extProjectID string) (*GetFeasibilityResponse, error) {
 	res := &GetFeasibilityResponse{}
 	path := fmt.Sprintf("/projects/%s/feasibility", extProjectID)
-	err := c.requestAndParseResponse("GET", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", path, nil, res)
 	return res, err
 }
 
+// WaitForFeasibility polls GetFeasibilityWithContext every pollInterval until the
+// feasibility status is FeasibilityStatusReady, the context is cancelled, or a call
+// returns an error. It replaces manually re-calling GetFeasibility every couple of minutes.
+func (c *Client) WaitForFeasibility(ctx context.Context, extProjectID string, pollInterval time.Duration) (*GetFeasibilityResponse, error) {
+	for {
+		res, err := c.GetFeasibilityWithContext(ctx, extProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if res.Feasibility.Status == FeasibilityStatusReady {
+			return res, nil
+		}
+		c.logger().Debug("samplify: feasibility still processing", "extProjectID", extProjectID, "status", res.Feasibility.Status)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
 // GetCountries ... Get the list of supported countries and languages in each country.
 func (c *Client) GetCountries() (*GetCountriesResponse, error) {
+	return c.GetCountriesWithContext(context.Background())
+}
+
+// GetCountriesWithContext ... Get the list of supported countries and languages in each country.
+func (c *Client) GetCountriesWithContext(ctx context.Context) (*GetCountriesResponse, error) {
 	res := &GetCountriesResponse{}
-	err := c.requestAndParseResponse("GET", "/countries", nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", "/countries", nil, res)
 	return res, err
 }
 
 // GetAttributes ... Get the list of supported attributes for a country and language. This data is required to build up the Quota Plan.
 func (c *Client) GetAttributes(countryCode, languageCode string) (*GetAttributesResponse, error) {
+	return c.GetAttributesWithContext(context.Background(), countryCode, languageCode)
+}
+
+// GetAttributesWithContext ... Get the list of supported attributes for a country and language. This data is required to build up the Quota Plan.
+func (c *Client) GetAttributesWithContext(ctx context.Context, countryCode, languageCode string) (*GetAttributesResponse, error) {
 	res := &GetAttributesResponse{}
 	path := fmt.Sprintf("/attributes/%s/%s", countryCode, languageCode)
-	err := c.requestAndParseResponse("GET", path, nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", path, nil, res)
 	return res, err
 }
 
 // GetSurveyTopics ... Get the list of supported Survey Topics for a project. This data is required to setup a project.
 func (c *Client) GetSurveyTopics() (*GetSurveyTopicsResponse, error) {
+	return c.GetSurveyTopicsWithContext(context.Background())
+}
+
+// GetSurveyTopicsWithContext ... Get the list of supported Survey Topics for a project. This data is required to setup a project.
+func (c *Client) GetSurveyTopicsWithContext(ctx context.Context) (*GetSurveyTopicsResponse, error) {
 	res := &GetSurveyTopicsResponse{}
-	err := c.requestAndParseResponse("GET", "/categories/surveyTopics", nil, res)
+	err := c.requestAndParseResponse(ctx, "GET", "/categories/surveyTopics", nil, res)
 	return res, err
 }
 
 // GetAuth ... Access token is automatically acquired. This is just for debug purposes.
 func (c *Client) GetAuth() (TokenResponse, error) {
-	err := c.requestAndParseToken()
+	return c.GetAuthWithContext(context.Background())
+}
+
+// GetAuthWithContext ... Access token is automatically acquired. This is just for debug purposes.
+func (c *Client) GetAuthWithContext(ctx context.Context) (TokenResponse, error) {
+	err := c.refreshToken(ctx)
 	if err != nil {
 		return TokenResponse{}, err
 	}
-	return c.Auth, err
+	return c.currentAuth(), nil
 }
 
-func (c *Client) requestAndParseResponse(method, url string, body interface{}, resObj interface{}) error {
-	ar, err := c.request(method, url, body)
+func (c *Client) requestAndParseResponse(ctx context.Context, method, url string, body interface{}, resObj interface{}) error {
+	ar, err := c.request(ctx, method, url, body)
 	if err != nil {
 		if ar != nil {
 			json.Unmarshal(ar.Body, &resObj)
@@ -180,38 +312,68 @@ func (c *Client) requestAndParseResponse(method, url string, body interface{}, r
 	return nil
 }
 
-func (c *Client) request(method, url string, body interface{}) (*APIResponse, error) {
-	if c.Auth.AccessTokenExpired() {
-		err := c.requestAndParseToken()
+func (c *Client) request(ctx context.Context, method, url string, body interface{}) (*APIResponse, error) {
+	if c.authExpired() {
+		err := c.refreshToken(ctx)
 		if err != nil {
 			return nil, err
 		}
 	}
-	ar, err := sendRequest(c.Options.APIBaseURL, method, url, c.Auth.AccessToken, body)
+	ar, err := c.sendRequestWithRetry(ctx, method, url, body)
 	errResp, ok := err.(*ErrorResponse)
 	if ok && errResp.HTTPCode == http.StatusUnauthorized {
-		err := c.requestAndParseToken()
+		err := c.refreshToken(ctx)
 		if err != nil {
 			return nil, err
 		}
-		return sendRequest(c.Options.APIBaseURL, method, url, c.Auth.AccessToken, body)
+		ar, err = c.sendRequestWithRetry(ctx, method, url, body)
 	}
-	return ar, err
+	return ar, wrapError(err, ar, url)
 }
 
-func (c *Client) requestAndParseToken() error {
-	log.Printf("Acquiring access token for %v", c.Credentials.ClientID)
-	t := time.Now()
-	ar, err := sendRequest(c.Options.AuthURL, "POST", "", "", c.Credentials)
-	if err != nil {
-		return err
+// sendRequestWithRetry sends a single request, retrying according to c.retryPolicy()
+// when the response is eligible (idempotent GETs and 5xx/429 responses by default).
+func (c *Client) sendRequestWithRetry(ctx context.Context, method, url string, body interface{}) (*APIResponse, error) {
+	policy := c.retryPolicy()
+	var ar *APIResponse
+	var err error
+	for attempt := 0; ; attempt++ {
+		ar, err = sendRequest(ctx, c.httpClient(), c.Options.APIBaseURL, method, url, c.currentAuth().AccessToken, body)
+		if policy.RequestAttempt != nil {
+			statusCode := 0
+			if ar != nil {
+				statusCode = ar.StatusCode
+			}
+			policy.RequestAttempt(RetryAttempt{Method: method, URL: url, Attempt: attempt, StatusCode: statusCode, Err: err})
+		}
+		if !policy.shouldRetry(method, ar, err, attempt) {
+			if err != nil {
+				c.logger().Warn("samplify: request failed", "method", method, "url", url, "error", err)
+			}
+			return ar, err
+		}
+		c.logger().Warn("samplify: retrying request", "method", method, "url", url, "attempt", attempt, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ar, ctx.Err()
+		case <-time.After(policy.backoff(ar, attempt)):
+		}
 	}
-	err = json.Unmarshal(ar.Body, &c.Auth)
-	if err != nil {
-		return err
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.Options.HTTPClient != nil {
+		return c.Options.HTTPClient
 	}
-	c.Auth.Acquired = &t
-	return nil
+	return http.DefaultClient
+}
+
+func (c *Client) retryPolicy() *RetryPolicy {
+	if c.Options.RetryPolicy != nil {
+		return c.Options.RetryPolicy
+	}
+	return DefaultRetryPolicy()
 }
 
 // NewClient returns an API client. If "options" is nil, default values will be used.
@@ -227,4 +389,4 @@ func NewClient(clientID, username, passsword string, options *ClientOptions) *Cl
 		},
 		Options: *options,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,72 @@
+package samplify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestAbortsOnContextCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := &Client{
+		Auth: TokenResponse{AccessToken: "token", Acquired: &now, ExpiresIn: 3600},
+		Options: ClientOptions{
+			APIBaseURL: srv.URL,
+			RetryPolicy: &RetryPolicy{
+				MaxAttempts:    1000,
+				InitialBackoff: 20 * time.Millisecond,
+				MaxBackoff:     20 * time.Millisecond,
+				Multiplier:     1,
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetAllProjectsWithContext(ctx)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > 2*time.Second {
+		t.Fatalf("request took %v after its context expired, want well under 2s", elapsed)
+	}
+}
+
+func TestWaitForFeasibilityStopsOnCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"feasibility":{"status":"PROCESSING"}}`))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := &Client{
+		Auth:    TokenResponse{AccessToken: "token", Acquired: &now, ExpiresIn: 3600},
+		Options: ClientOptions{APIBaseURL: srv.URL},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 80*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.WaitForFeasibility(ctx, "proj-1", 50*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > 2*time.Second {
+		t.Fatalf("WaitForFeasibility took %v after its context expired, want well under 2s", elapsed)
+	}
+}
@@ -0,0 +1,97 @@
+package samplify
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for well-known Samplify API error conditions. Use errors.Is to
+// check for them instead of inspecting HTTPCode:
+//
+//	if errors.Is(err, samplify.ErrFeasibilityProcessing) { ... }
+var (
+	ErrProjectNotFound       = errors.New("samplify: project not found")
+	ErrLineItemNotFound      = errors.New("samplify: line item not found")
+	ErrInvalidQuotaPlan      = errors.New("samplify: invalid quota plan")
+	ErrFeasibilityProcessing = errors.New("samplify: feasibility not yet computed")
+	ErrRateLimited           = errors.New("samplify: rate limited")
+	ErrUnauthorized          = errors.New("samplify: unauthorized")
+)
+
+// errorCodeSentinels maps Samplify's documented ErrorResponse.Code values to a
+// sentinel error.
+var errorCodeSentinels = map[string]error{
+	"PROJECT_NOT_FOUND":      ErrProjectNotFound,
+	"LINE_ITEM_NOT_FOUND":    ErrLineItemNotFound,
+	"INVALID_QUOTA_PLAN":     ErrInvalidQuotaPlan,
+	"FEASIBILITY_PROCESSING": ErrFeasibilityProcessing,
+}
+
+// statusSentinels maps HTTP status codes to a sentinel error, used when the
+// response body carries no recognized Code. http.StatusNotFound is handled
+// separately by notFoundSentinel, since projects and line items 404 identically
+// and need the request path to tell them apart.
+var statusSentinels = map[int]error{
+	http.StatusTooManyRequests: ErrRateLimited,
+	http.StatusUnauthorized:    ErrUnauthorized,
+}
+
+// notFoundSentinel picks ErrProjectNotFound or ErrLineItemNotFound for a 404 with no
+// recognized Code, based on whether path addresses a specific line item (e.g.
+// "/projects/{id}/lineItems/{id}") as opposed to the line item collection (e.g.
+// "/projects/{id}/lineItems", used by AddLineItem and GetAllLineItems, whose 404
+// means the project itself wasn't found).
+func notFoundSentinel(path string) error {
+	if strings.Contains(path, "/lineItems/") {
+		return ErrLineItemNotFound
+	}
+	return ErrProjectNotFound
+}
+
+// APIError wraps an ErrorResponse with the original APIResponse (status, headers,
+// raw body) and a sentinel error, so callers can use errors.Is/errors.As instead of
+// type-asserting *ErrorResponse and sniffing HTTPCode.
+type APIError struct {
+	*ErrorResponse
+	Response *APIResponse
+
+	sentinel error
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return e.ErrorResponse.Error()
+}
+
+// Unwrap allows errors.Is(err, ErrProjectNotFound) and similar checks to succeed.
+func (e *APIError) Unwrap() error {
+	return e.sentinel
+}
+
+// newAPIError wraps errResp, which came from requesting path into ar, into an
+// *APIError, mapping it to the most specific known sentinel error. If no sentinel
+// matches, errResp itself is used so errors.As(err, &errResp) still works.
+func newAPIError(errResp *ErrorResponse, ar *APIResponse, path string) *APIError {
+	sentinel := error(errResp)
+	switch {
+	case errorCodeSentinels[errResp.Code] != nil:
+		sentinel = errorCodeSentinels[errResp.Code]
+	case errResp.HTTPCode == http.StatusNotFound:
+		sentinel = notFoundSentinel(path)
+	case statusSentinels[errResp.HTTPCode] != nil:
+		sentinel = statusSentinels[errResp.HTTPCode]
+	}
+	return &APIError{ErrorResponse: errResp, Response: ar, sentinel: sentinel}
+}
+
+// wrapError converts err into an *APIError when it is an *ErrorResponse produced by
+// requesting path against the Samplify API, leaving other errors (e.g. network
+// failures, context cancellation) untouched.
+func wrapError(err error, ar *APIResponse, path string) error {
+	errResp, ok := err.(*ErrorResponse)
+	if !ok {
+		return err
+	}
+	return newAPIError(errResp, ar, path)
+}
@@ -0,0 +1,128 @@
+package samplify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestWrapErrorMapsKnownCodesToSentinels(t *testing.T) {
+	cases := []struct {
+		code string
+		want error
+	}{
+		{"PROJECT_NOT_FOUND", ErrProjectNotFound},
+		{"LINE_ITEM_NOT_FOUND", ErrLineItemNotFound},
+		{"INVALID_QUOTA_PLAN", ErrInvalidQuotaPlan},
+		{"FEASIBILITY_PROCESSING", ErrFeasibilityProcessing},
+	}
+	for _, c := range cases {
+		errResp := &ErrorResponse{HTTPCode: http.StatusBadRequest, Code: c.code, Message: "boom"}
+		err := wrapError(errResp, nil, "/projects")
+		if !errors.Is(err, c.want) {
+			t.Errorf("wrapError with Code=%q: errors.Is(err, %v) = false, want true", c.code, c.want)
+		}
+	}
+}
+
+func TestWrapErrorFallsBackToStatusSentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		path   string
+		want   error
+	}{
+		{http.StatusNotFound, "/projects/proj-1", ErrProjectNotFound},
+		{http.StatusNotFound, "/projects/proj-1/lineItems/li-1", ErrLineItemNotFound},
+		{http.StatusNotFound, "/projects/proj-1/lineItems", ErrProjectNotFound},
+		{http.StatusTooManyRequests, "/projects", ErrRateLimited},
+		{http.StatusUnauthorized, "/projects", ErrUnauthorized},
+	}
+	for _, c := range cases {
+		errResp := &ErrorResponse{HTTPCode: c.status, Message: "boom"}
+		err := wrapError(errResp, nil, c.path)
+		if !errors.Is(err, c.want) {
+			t.Errorf("wrapError with HTTPCode=%d path=%q: errors.Is(err, %v) = false, want true", c.status, c.path, c.want)
+		}
+	}
+}
+
+func TestWrapErrorWithNoMatchStillSupportsErrorsAs(t *testing.T) {
+	errResp := &ErrorResponse{HTTPCode: http.StatusInternalServerError, Message: "server exploded"}
+	err := wrapError(errResp, nil, "/projects")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatal("errors.As(err, &apiErr) = false, want true")
+	}
+
+	var gotErrResp *ErrorResponse
+	if !errors.As(err, &gotErrResp) {
+		t.Fatal("errors.As(err, &gotErrResp) = false, want true")
+	}
+	if gotErrResp.Message != "server exploded" {
+		t.Errorf("gotErrResp.Message = %q, want %q", gotErrResp.Message, "server exploded")
+	}
+
+	for _, sentinel := range []error{ErrProjectNotFound, ErrRateLimited, ErrUnauthorized} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("errors.Is(err, %v) = true, want false for an unmatched error", sentinel)
+		}
+	}
+}
+
+func TestWrapErrorLeavesNonErrorResponseUntouched(t *testing.T) {
+	cases := []error{
+		context.Canceled,
+		errors.New("dial tcp: connection refused"),
+	}
+	for _, in := range cases {
+		if got := wrapError(in, nil, "/projects"); got != in {
+			t.Errorf("wrapError(%v) = %v, want it returned unchanged", in, got)
+		}
+	}
+}
+
+func TestAPIErrorPreservesResponse(t *testing.T) {
+	errResp := &ErrorResponse{HTTPCode: http.StatusNotFound, Code: "PROJECT_NOT_FOUND", Message: "nope"}
+	ar := &APIResponse{StatusCode: http.StatusNotFound}
+
+	err := wrapError(errResp, ar, "/projects/proj-1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatal("errors.As(err, &apiErr) = false, want true")
+	}
+	if apiErr.Response != ar {
+		t.Error("APIError.Response was not preserved")
+	}
+	if apiErr.Error() != errResp.Error() {
+		t.Errorf("APIError.Error() = %q, want %q", apiErr.Error(), errResp.Error())
+	}
+}
+
+func TestWrapErrorDoesNotMisreportLineItemNotFoundAsProjectNotFound(t *testing.T) {
+	errResp := &ErrorResponse{HTTPCode: http.StatusNotFound, Message: "not found"}
+	err := wrapError(errResp, nil, "/projects/proj-1/lineItems/li-1")
+
+	if errors.Is(err, ErrProjectNotFound) {
+		t.Error("errors.Is(err, ErrProjectNotFound) = true for a line-item-not-found response, want false")
+	}
+	if !errors.Is(err, ErrLineItemNotFound) {
+		t.Error("errors.Is(err, ErrLineItemNotFound) = false, want true")
+	}
+}
+
+func TestWrapErrorMapsCollectionEndpoint404ToProjectNotFound(t *testing.T) {
+	// AddLineItem and GetAllLineItems both hit the /lineItems collection endpoint
+	// (no line item ID in the path); a 404 there means the project doesn't exist,
+	// not that some not-yet-referenced line item is missing.
+	errResp := &ErrorResponse{HTTPCode: http.StatusNotFound, Message: "not found"}
+	err := wrapError(errResp, nil, "/projects/proj-1/lineItems")
+
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Error("errors.Is(err, ErrProjectNotFound) = false, want true for a 404 on the lineItems collection endpoint")
+	}
+	if errors.Is(err, ErrLineItemNotFound) {
+		t.Error("errors.Is(err, ErrLineItemNotFound) = true, want false for a 404 on the lineItems collection endpoint")
+	}
+}
@@ -0,0 +1,185 @@
+package samplify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrIteratorDone is returned by ProjectIterator.Next and LineItemIterator.Next when
+// there are no more items to return, mirroring google.golang.org/api/iterator.Done.
+var ErrIteratorDone = errors.New("samplify: no more items in iterator")
+
+// defaultPageSize is used when a ListProjectsOptions or ListLineItemsOptions does
+// not set Limit.
+const defaultPageSize = 50
+
+// listQuery builds the offset/limit/sort/filter query string shared by
+// ListProjectsOptions and ListLineItemsOptions.
+func listQuery(offset, limit int, sort, filter string) url.Values {
+	v := url.Values{}
+	v.Set("offset", fmt.Sprintf("%d", offset))
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	v.Set("limit", fmt.Sprintf("%d", limit))
+	if sort != "" {
+		v.Set("sort", sort)
+	}
+	if filter != "" {
+		v.Set("filter", filter)
+	}
+	return v
+}
+
+// pageIterator implements the fetch/buffer/advance mechanics shared by
+// ProjectIterator and LineItemIterator. fetch retrieves the page starting at offset,
+// of at most limit items; a short or empty page ends the iteration.
+type pageIterator[T any] struct {
+	offset int
+	limit  int
+	fetch  func(ctx context.Context, offset, limit int) ([]*T, error)
+
+	buf  []*T
+	idx  int
+	done bool
+}
+
+func newPageIterator[T any](offset, limit int, fetch func(ctx context.Context, offset, limit int) ([]*T, error)) *pageIterator[T] {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	return &pageIterator[T]{offset: offset, limit: limit, fetch: fetch}
+}
+
+func (it *pageIterator[T]) next(ctx context.Context) (*T, error) {
+	for it.idx >= len(it.buf) {
+		if it.done {
+			return nil, ErrIteratorDone
+		}
+		page, err := it.fetch(ctx, it.offset, it.limit)
+		if err != nil {
+			return nil, err
+		}
+		it.buf = page
+		it.idx = 0
+		it.offset += len(page)
+		if len(page) == 0 || len(page) < it.limit {
+			it.done = true
+		}
+	}
+	v := it.buf[it.idx]
+	it.idx++
+	return v, nil
+}
+
+func (it *pageIterator[T]) all(ctx context.Context) ([]*T, error) {
+	var all []*T
+	for {
+		v, err := it.next(ctx)
+		if errors.Is(err, ErrIteratorDone) {
+			return all, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, v)
+	}
+}
+
+// ListProjectsOptions controls pagination, sorting, and filtering for ListProjects.
+type ListProjectsOptions struct {
+	Offset int
+	Limit  int
+	Sort   string
+	Filter string
+}
+
+func (o ListProjectsOptions) query() url.Values {
+	return listQuery(o.Offset, o.Limit, o.Sort, o.Filter)
+}
+
+// ProjectIterator streams projects from GetAllProjects, transparently fetching
+// additional pages as needed.
+type ProjectIterator struct {
+	inner *pageIterator[Project]
+}
+
+// ListProjects returns a ProjectIterator over the caller's projects. A nil opts is
+// equivalent to the zero value.
+func (c *Client) ListProjects(opts *ListProjectsOptions) *ProjectIterator {
+	if opts == nil {
+		opts = &ListProjectsOptions{}
+	}
+	o := *opts
+	fetch := func(ctx context.Context, offset, limit int) ([]*Project, error) {
+		o.Offset, o.Limit = offset, limit
+		path := fmt.Sprintf("/projects?%s", o.query().Encode())
+		res := &GetAllProjectsResponse{}
+		if err := c.requestAndParseResponse(ctx, "GET", path, nil, res); err != nil {
+			return nil, err
+		}
+		return res.Projects, nil
+	}
+	return &ProjectIterator{inner: newPageIterator(o.Offset, o.Limit, fetch)}
+}
+
+// Next returns the next project, fetching another page if the current one has been
+// exhausted. It returns ErrIteratorDone once all projects have been returned.
+func (it *ProjectIterator) Next(ctx context.Context) (*Project, error) {
+	return it.inner.next(ctx)
+}
+
+// All drains the iterator, returning every remaining project.
+func (it *ProjectIterator) All(ctx context.Context) ([]*Project, error) {
+	return it.inner.all(ctx)
+}
+
+// ListLineItemsOptions controls pagination, sorting, and filtering for ListLineItems.
+type ListLineItemsOptions struct {
+	Offset int
+	Limit  int
+	Sort   string
+	Filter string
+}
+
+func (o ListLineItemsOptions) query() url.Values {
+	return listQuery(o.Offset, o.Limit, o.Sort, o.Filter)
+}
+
+// LineItemIterator streams line items from GetAllLineItems, transparently fetching
+// additional pages as needed.
+type LineItemIterator struct {
+	inner *pageIterator[LineItemResponse]
+}
+
+// ListLineItems returns a LineItemIterator over extProjectID's line items. A nil
+// opts is equivalent to the zero value.
+func (c *Client) ListLineItems(extProjectID string, opts *ListLineItemsOptions) *LineItemIterator {
+	if opts == nil {
+		opts = &ListLineItemsOptions{}
+	}
+	o := *opts
+	fetch := func(ctx context.Context, offset, limit int) ([]*LineItemResponse, error) {
+		o.Offset, o.Limit = offset, limit
+		path := fmt.Sprintf("/projects/%s/lineItems?%s", extProjectID, o.query().Encode())
+		res := &GetAllLineItemsResponse{}
+		if err := c.requestAndParseResponse(ctx, "GET", path, nil, res); err != nil {
+			return nil, err
+		}
+		return res.LineItems, nil
+	}
+	return &LineItemIterator{inner: newPageIterator(o.Offset, o.Limit, fetch)}
+}
+
+// Next returns the next line item, fetching another page if the current one has
+// been exhausted. It returns ErrIteratorDone once all line items have been returned.
+func (it *LineItemIterator) Next(ctx context.Context) (*LineItemResponse, error) {
+	return it.inner.next(ctx)
+}
+
+// All drains the iterator, returning every remaining line item.
+func (it *LineItemIterator) All(ctx context.Context) ([]*LineItemResponse, error) {
+	return it.inner.all(ctx)
+}
@@ -0,0 +1,156 @@
+package samplify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestProjectIteratorPaginatesAndTerminates(t *testing.T) {
+	total := 7
+	limit := 3
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		var projects []*Project
+		for i := offset; i < offset+limit && i < total; i++ {
+			projects = append(projects, &Project{ExtProjectID: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(GetAllProjectsResponse{Projects: projects})
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := &Client{
+		Auth:    TokenResponse{AccessToken: "token", Acquired: &now, ExpiresIn: 3600},
+		Options: ClientOptions{APIBaseURL: srv.URL},
+	}
+
+	it := c.ListProjects(&ListProjectsOptions{Limit: limit})
+
+	var seen []string
+	for {
+		p, err := it.Next(context.Background())
+		if err == ErrIteratorDone {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		seen = append(seen, p.ExtProjectID)
+	}
+
+	if len(seen) != total {
+		t.Fatalf("collected %d projects, want %d", len(seen), total)
+	}
+	for i, id := range seen {
+		if id != strconv.Itoa(i) {
+			t.Errorf("seen[%d] = %q, want %q", i, id, strconv.Itoa(i))
+		}
+	}
+
+	wantRequests := 3 // pages of 3, 3, 1
+	if requests != wantRequests {
+		t.Errorf("server received %d requests, want %d", requests, wantRequests)
+	}
+
+	if _, err := it.Next(context.Background()); err != ErrIteratorDone {
+		t.Errorf("Next after exhaustion = %v, want ErrIteratorDone", err)
+	}
+}
+
+func TestProjectIteratorHonorsInitialOffset(t *testing.T) {
+	var gotOffset string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotOffset = r.URL.Query().Get("offset")
+		json.NewEncoder(w).Encode(GetAllProjectsResponse{Projects: []*Project{{ExtProjectID: "x"}}})
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := &Client{
+		Auth:    TokenResponse{AccessToken: "token", Acquired: &now, ExpiresIn: 3600},
+		Options: ClientOptions{APIBaseURL: srv.URL},
+	}
+
+	it := c.ListProjects(&ListProjectsOptions{Offset: 100, Limit: 10})
+	if _, err := it.Next(context.Background()); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if gotOffset != "100" {
+		t.Errorf("first fetch requested offset=%q, want %q", gotOffset, "100")
+	}
+}
+
+func TestProjectIteratorAll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if offset > 0 {
+			json.NewEncoder(w).Encode(GetAllProjectsResponse{})
+			return
+		}
+		json.NewEncoder(w).Encode(GetAllProjectsResponse{Projects: []*Project{
+			{ExtProjectID: "a"},
+			{ExtProjectID: "b"},
+		}})
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := &Client{
+		Auth:    TokenResponse{AccessToken: "token", Acquired: &now, ExpiresIn: 3600},
+		Options: ClientOptions{APIBaseURL: srv.URL},
+	}
+
+	all, err := c.ListProjects(nil).All(context.Background())
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("All returned %d projects, want 2", len(all))
+	}
+}
+
+func TestLineItemIteratorPaginatesAndTerminates(t *testing.T) {
+	total := 5
+	limit := 2
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		var items []*LineItemResponse
+		for i := offset; i < offset+limit && i < total; i++ {
+			items = append(items, &LineItemResponse{LineItem: LineItem{ExtLineItemID: strconv.Itoa(i)}})
+		}
+		json.NewEncoder(w).Encode(GetAllLineItemsResponse{LineItems: items})
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	c := &Client{
+		Auth:    TokenResponse{AccessToken: "token", Acquired: &now, ExpiresIn: 3600},
+		Options: ClientOptions{APIBaseURL: srv.URL},
+	}
+
+	all, err := c.ListLineItems("proj-1", &ListLineItemsOptions{Limit: limit}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(all) != total {
+		t.Fatalf("collected %d line items, want %d", len(all), total)
+	}
+
+	wantRequests := 3 // pages of 2, 2, 1
+	if requests != wantRequests {
+		t.Errorf("server received %d requests, want %d", requests, wantRequests)
+	}
+}
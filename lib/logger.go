@@ -0,0 +1,42 @@
+package samplify
+
+// Logger is a minimal structured logging interface used internally by the Client
+// for auth acquisition, retries, non-2xx responses, and feasibility polling.
+// Implementations receive a message and alternating key/value pairs, following the
+// convention used by slog, zap's SugaredLogger, and similar structured loggers.
+//
+// Callers must never be passed Credentials.Password or a bearer access token as a
+// value; the Client redacts both before logging.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// noopLogger discards all log output and is used when ClientOptions.Logger is nil.
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...interface{}) {}
+func (noopLogger) Info(string, ...interface{})  {}
+func (noopLogger) Warn(string, ...interface{})  {}
+func (noopLogger) Error(string, ...interface{}) {}
+
+func (c *Client) logger() Logger {
+	if c.Options.Logger != nil {
+		return c.Options.Logger
+	}
+	return noopLogger{}
+}
+
+// redactToken replaces all but the last 4 characters of a bearer token so it can be
+// included in log output without leaking the credential.
+func redactToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	if len(token) <= 4 {
+		return "****"
+	}
+	return "****" + token[len(token)-4:]
+}
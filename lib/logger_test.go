@@ -0,0 +1,62 @@
+package samplify
+
+import "testing"
+
+func TestRedactToken(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"abcd", "****"},
+		{"abcdef1234", "****1234"},
+	}
+	for _, c := range cases {
+		if got := redactToken(c.in); got != c.want {
+			t.Errorf("redactToken(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestRedactTokenNeverContainsFullToken(t *testing.T) {
+	token := "super-secret-access-token-value"
+	redacted := redactToken(token)
+	if redacted == token {
+		t.Fatalf("redactToken returned the token unchanged")
+	}
+	if len(redacted) >= len(token) {
+		t.Fatalf("redactToken(%q) = %q, expected it to be shorter than the input", token, redacted)
+	}
+}
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(msg string, keyvals ...interface{}) { l.messages = append(l.messages, msg) }
+func (l *recordingLogger) Info(msg string, keyvals ...interface{})  { l.messages = append(l.messages, msg) }
+func (l *recordingLogger) Warn(msg string, keyvals ...interface{})  { l.messages = append(l.messages, msg) }
+func (l *recordingLogger) Error(msg string, keyvals ...interface{}) { l.messages = append(l.messages, msg) }
+
+func TestClientLoggerDefaultsToNoop(t *testing.T) {
+	c := &Client{}
+	if _, ok := c.logger().(noopLogger); !ok {
+		t.Errorf("logger() = %T, want noopLogger when Options.Logger is unset", c.logger())
+	}
+
+	// noopLogger must never panic, regardless of how it's called.
+	c.logger().Debug("msg", "k", "v")
+	c.logger().Info("msg")
+	c.logger().Warn("msg", "k", "v", "k2", "v2")
+	c.logger().Error("msg")
+}
+
+func TestClientLoggerUsesConfiguredLogger(t *testing.T) {
+	l := &recordingLogger{}
+	c := &Client{Options: ClientOptions{Logger: l}}
+
+	c.logger().Info("hello")
+	if len(l.messages) != 1 || l.messages[0] != "hello" {
+		t.Errorf("messages = %v, want [\"hello\"]", l.messages)
+	}
+}
@@ -0,0 +1,121 @@
+package samplify
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAttempt describes a single attempt made while executing a request, and is
+// passed to RetryPolicy.RequestAttempt for logging/metrics purposes.
+type RetryAttempt struct {
+	Method     string
+	URL        string
+	Attempt    int
+	StatusCode int
+	Err        error
+}
+
+// RetryPolicy controls how the Client retries failed requests. A nil *RetryPolicy
+// on ClientOptions causes DefaultRetryPolicy to be used.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request will be sent, including
+	// the initial attempt.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the backoff after each attempt.
+	Multiplier float64
+
+	// Jitter randomizes the computed backoff to avoid thundering-herd retries.
+	Jitter bool
+
+	// RequestAttempt, if set, is called after every attempt (including the last)
+	// so callers can log or record metrics.
+	RequestAttempt func(RetryAttempt)
+}
+
+// DefaultRetryPolicy retries idempotent GETs and 5xx/429 responses with exponential
+// backoff and jitter, starting at 100ms and capping at 10s, for up to 4 attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         true,
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(method string, ar *APIResponse, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts-1 {
+		return false
+	}
+	if err == nil {
+		return false
+	}
+	errResp, ok := err.(*ErrorResponse)
+	if !ok {
+		return false
+	}
+	if errResp.HTTPCode == http.StatusTooManyRequests {
+		return true
+	}
+	if errResp.HTTPCode >= 500 && errResp.HTTPCode < 600 && isIdempotent(method) {
+		return true
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns how long to wait before the given (zero-indexed) retry attempt,
+// honoring a Retry-After header on ar if one is present.
+func (p *RetryPolicy) backoff(ar *APIResponse, attempt int) time.Duration {
+	if ar != nil {
+		if d, ok := retryAfter(ar.Header); ok {
+			return d
+		}
+	}
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt))
+	if max := float64(p.MaxBackoff); d > max {
+		d = max
+	}
+	if p.Jitter {
+		d = d/2 + rand.Float64()*d/2
+	}
+	return time.Duration(d)
+}
+
+func retryAfter(header http.Header) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,112 @@
+package samplify
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	cases := []struct {
+		name    string
+		method  string
+		err     error
+		attempt int
+		want    bool
+	}{
+		{"get 500 retries", "GET", &ErrorResponse{HTTPCode: http.StatusInternalServerError}, 0, true},
+		{"post 500 does not retry", "POST", &ErrorResponse{HTTPCode: http.StatusInternalServerError}, 0, false},
+		{"post 429 retries", "POST", &ErrorResponse{HTTPCode: http.StatusTooManyRequests}, 0, true},
+		{"get 404 does not retry", "GET", &ErrorResponse{HTTPCode: http.StatusNotFound}, 0, false},
+		{"no error does not retry", "GET", nil, 0, false},
+		{"network error does not retry", "GET", errNetwork{}, 0, false},
+		{"last attempt does not retry", "GET", &ErrorResponse{HTTPCode: http.StatusInternalServerError}, policy.MaxAttempts - 1, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := policy.shouldRetry(c.method, nil, c.err, c.attempt); got != c.want {
+				t.Errorf("shouldRetry(%q, %v, %d) = %v, want %v", c.method, c.err, c.attempt, got, c.want)
+			}
+		})
+	}
+}
+
+type errNetwork struct{}
+
+func (errNetwork) Error() string { return "network error" }
+
+func TestRetryPolicyBackoffBounds(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+		Multiplier:     2,
+		Jitter:         true,
+	}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		for i := 0; i < 20; i++ {
+			d := policy.backoff(nil, attempt)
+			if d < 0 || d > policy.MaxBackoff {
+				t.Fatalf("backoff(attempt=%d) = %v, want within [0, %v]", attempt, d, policy.MaxBackoff)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyBackoffHonorsRetryAfter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	header := http.Header{}
+	header.Set("Retry-After", "3")
+	ar := &APIResponse{Header: header}
+
+	got := policy.backoff(ar, 0)
+	if got != 3*time.Second {
+		t.Errorf("backoff with Retry-After header = %v, want 3s", got)
+	}
+}
+
+func TestRetryAfterParsesSeconds(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "5")
+
+	d, ok := retryAfter(header)
+	if !ok {
+		t.Fatal("retryAfter: expected ok=true for integer seconds")
+	}
+	if d != 5*time.Second {
+		t.Errorf("retryAfter = %v, want 5s", d)
+	}
+}
+
+func TestRetryAfterParsesHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	header := http.Header{}
+	header.Set("Retry-After", future.Format(http.TimeFormat))
+
+	d, ok := retryAfter(header)
+	if !ok {
+		t.Fatal("retryAfter: expected ok=true for an HTTP-date")
+	}
+	if d <= 0 || d > 10*time.Second {
+		t.Errorf("retryAfter = %v, want roughly 10s", d)
+	}
+}
+
+func TestRetryAfterMissingOrInvalid(t *testing.T) {
+	if _, ok := retryAfter(nil); ok {
+		t.Error("retryAfter(nil) = ok, want !ok")
+	}
+	if _, ok := retryAfter(http.Header{}); ok {
+		t.Error("retryAfter(empty) = ok, want !ok")
+	}
+
+	header := http.Header{}
+	header.Set("Retry-After", "not-a-valid-value")
+	if _, ok := retryAfter(header); ok {
+		t.Error("retryAfter(garbage) = ok, want !ok")
+	}
+}
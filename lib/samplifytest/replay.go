@@ -0,0 +1,156 @@
+package samplifytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sync"
+)
+
+type fixtureEntry struct {
+	Request  fixtureRequest  `json:"request"`
+	Response fixtureResponse `json:"response"`
+}
+
+type fixtureRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+type fixtureResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// replayTransport implements http.RoundTripper, replaying fixtureEntries recorded
+// by a recordingTransport in order. It does not attempt to match requests by method
+// or path beyond bookkeeping for error messages, since tests are expected to drive
+// the client through the same sequence of calls it was recorded from.
+type replayTransport struct {
+	mu      sync.Mutex
+	entries []fixtureEntry
+	idx     int
+}
+
+// NewReplayClient returns an *http.Client that replays the request/response
+// fixtures recorded at fixturePath (e.g. by NewRecordingClient), in order, without
+// making any real network calls. It is meant to be assigned to
+// ClientOptions.HTTPClient for offline, deterministic tests:
+//
+//	client.Options.HTTPClient = samplifytest.NewReplayClient("testdata/create_project.json")
+func NewReplayClient(fixturePath string) *http.Client {
+	entries, err := loadFixtures(fixturePath)
+	if err != nil {
+		panic(fmt.Sprintf("samplifytest: failed to load fixtures from %s: %v", fixturePath, err))
+	}
+	return &http.Client{Transport: &replayTransport{entries: entries}}
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.idx >= len(t.entries) {
+		return nil, fmt.Errorf("samplifytest: no more recorded responses (requested %s %s)", req.Method, req.URL.Path)
+	}
+	entry := t.entries[t.idx]
+	t.idx++
+
+	header := entry.Response.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		StatusCode: entry.Response.StatusCode,
+		Header:     header,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(entry.Response.Body))),
+		Request:    req,
+	}, nil
+}
+
+func loadFixtures(path string) ([]fixtureEntry, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []fixtureEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// recordingTransport wraps another http.RoundTripper, appending each request/
+// response pair it observes to a JSON fixture file after scrubbing secrets.
+type recordingTransport struct {
+	underlying http.RoundTripper
+	path       string
+
+	mu      sync.Mutex
+	entries []fixtureEntry
+}
+
+// NewRecordingClient returns an *http.Client that proxies through underlying (or
+// http.DefaultTransport if nil), appending every request/response pair to
+// fixturePath as JSON. The Authorization header and any "password" field in the
+// request or response body are scrubbed before being written, so fixtures are safe
+// to commit alongside the tests that replay them.
+func NewRecordingClient(underlying http.RoundTripper, fixturePath string) *http.Client {
+	if underlying == nil {
+		underlying = http.DefaultTransport
+	}
+	return &http.Client{Transport: &recordingTransport{underlying: underlying, path: fixturePath}}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.underlying.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return resp, nil
+	}
+
+	t.record(req, resp, body)
+	return resp, nil
+}
+
+func (t *recordingTransport) record(req *http.Request, resp *http.Response, body []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.entries = append(t.entries, fixtureEntry{
+		Request: fixtureRequest{Method: req.Method, Path: req.URL.Path},
+		Response: fixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       string(scrubBody(body)),
+		},
+	})
+
+	marshalled, err := json.MarshalIndent(t.entries, "", "  ")
+	if err != nil {
+		return
+	}
+	ioutil.WriteFile(t.path, marshalled, 0o644)
+}
+
+func scrubHeader(h http.Header) http.Header {
+	scrubbed := h.Clone()
+	scrubbed.Del("Authorization")
+	return scrubbed
+}
+
+var passwordFieldPattern = regexp.MustCompile(`(?i)"(password|accessToken)"\s*:\s*"[^"]*"`)
+
+func scrubBody(body []byte) []byte {
+	return passwordFieldPattern.ReplaceAll(body, []byte(`"$1":"REDACTED"`))
+}
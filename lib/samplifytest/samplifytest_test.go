@@ -0,0 +1,151 @@
+package samplifytest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerReturnsScriptedResponsesInOrder(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.On(http.MethodGet, "/projects",
+		Response{StatusCode: http.StatusOK, Body: map[string]string{"call": "first"}},
+		Response{StatusCode: http.StatusInternalServerError, Body: map[string]string{"call": "second"}},
+	)
+
+	for i, wantStatus := range []int{http.StatusOK, http.StatusInternalServerError, http.StatusInternalServerError} {
+		resp, err := http.Get(srv.URL + "/projects")
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != wantStatus {
+			t.Errorf("call %d: status = %d, want %d", i, resp.StatusCode, wantStatus)
+		}
+	}
+}
+
+func TestServerUnscriptedRouteReturns404(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestServerNetworkErrorInjection(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.On(http.MethodGet, "/boom", Response{NetworkError: true})
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	if _, err := client.Get(srv.URL + "/boom"); err == nil {
+		t.Fatal("expected a network-level error, got nil")
+	}
+}
+
+func TestUnauthorizedHelper(t *testing.T) {
+	r := Unauthorized()
+	if r.StatusCode != http.StatusUnauthorized {
+		t.Errorf("StatusCode = %d, want %d", r.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestRateLimitedEmitsIntegerSecondsRetryAfter(t *testing.T) {
+	r := RateLimited(2 * time.Second)
+	if r.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode = %d, want %d", r.StatusCode, http.StatusTooManyRequests)
+	}
+	got := r.Header.Get("Retry-After")
+	if _, err := strconv.Atoi(got); err != nil {
+		t.Fatalf("Retry-After = %q, want an integer number of seconds: %v", got, err)
+	}
+	if got != "2" {
+		t.Errorf("Retry-After = %q, want %q", got, "2")
+	}
+}
+
+func TestRecordAndReplayRoundTrip(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.On(http.MethodGet, "/projects", Response{StatusCode: http.StatusOK, Body: map[string]string{"extProjectId": "proj-1"}})
+
+	fixturePath := filepath.Join(t.TempDir(), "projects.json")
+	recorder := NewRecordingClient(nil, fixturePath)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/projects", nil)
+	req.Header.Set("Authorization", "Bearer super-secret-token")
+	resp, err := recorder.Do(req)
+	if err != nil {
+		t.Fatalf("recording request: %v", err)
+	}
+	recordedBody, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	fixtureBytes, err := ioutil.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	if strings.Contains(string(fixtureBytes), "super-secret-token") {
+		t.Error("fixture file contains the unscrubbed Authorization token")
+	}
+
+	replay := NewReplayClient(fixturePath)
+	replayReq, _ := http.NewRequest(http.MethodGet, "http://example.invalid/projects", nil)
+	replayResp, err := replay.Do(replayReq)
+	if err != nil {
+		t.Fatalf("replaying request: %v", err)
+	}
+	defer replayResp.Body.Close()
+
+	if replayResp.StatusCode != http.StatusOK {
+		t.Errorf("replay status = %d, want %d", replayResp.StatusCode, http.StatusOK)
+	}
+	replayedBody, _ := ioutil.ReadAll(replayResp.Body)
+	if string(replayedBody) != string(recordedBody) {
+		t.Errorf("replayed body = %q, want %q", replayedBody, recordedBody)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(replayedBody, &decoded); err != nil {
+		t.Fatalf("decoding replayed body: %v", err)
+	}
+	if decoded["extProjectId"] != "proj-1" {
+		t.Errorf("extProjectId = %q, want %q", decoded["extProjectId"], "proj-1")
+	}
+}
+
+func TestReplayClientErrorsOnceFixturesExhausted(t *testing.T) {
+	fixturePath := filepath.Join(t.TempDir(), "empty.json")
+	if err := ioutil.WriteFile(fixturePath, []byte(`[]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	replay := NewReplayClient(fixturePath)
+	req, _ := http.NewRequest(http.MethodGet, "http://example.invalid/projects", nil)
+	if _, err := replay.Do(req); err == nil {
+		t.Fatal("expected an error once fixtures are exhausted, got nil")
+	}
+}
+
+func TestScrubBodyRedactsPasswordField(t *testing.T) {
+	in := []byte(`{"clientId":"abc","password":"hunter2"}`)
+	out := scrubBody(in)
+	if strings.Contains(string(out), "hunter2") {
+		t.Errorf("scrubBody did not redact the password: %s", out)
+	}
+}
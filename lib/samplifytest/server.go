@@ -0,0 +1,107 @@
+// Package samplifytest provides an offline test harness for code built on top of
+// the samplify client: a scripted httptest.Server-based fake of the API, and a
+// record/replay http.RoundTripper for capturing and replaying real traffic in CI.
+package samplifytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Response is a scripted response for one call to a route registered on a Server.
+type Response struct {
+	StatusCode int
+	Body       interface{}
+	Header     http.Header
+
+	// NetworkError, if true, causes the server to close the connection without
+	// writing a response, simulating a transport-level failure (as opposed to an
+	// HTTP error status).
+	NetworkError bool
+}
+
+// Unauthorized is a convenience Response simulating an expired or invalid token, to
+// exercise a Client's token refresh path.
+func Unauthorized() Response {
+	return Response{StatusCode: http.StatusUnauthorized, Body: map[string]string{"message": "unauthorized"}}
+}
+
+// RateLimited is a convenience Response simulating a 429 with a Retry-After header,
+// to exercise a Client's retry policy.
+func RateLimited(retryAfter time.Duration) Response {
+	h := http.Header{}
+	h.Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+	return Response{StatusCode: http.StatusTooManyRequests, Header: h, Body: map[string]string{"message": "rate limited"}}
+}
+
+// Server is an httptest.Server-based fake of the Samplify API, driven by scripted
+// Responses registered via On. Routes with no scripted response return 404.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	routes map[string][]Response
+}
+
+// NewServer starts a Server. Callers must Close it when done, as with httptest.Server.
+func NewServer() *Server {
+	s := &Server{routes: make(map[string][]Response)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// On registers the responses to return, in order, for successive calls to method
+// and path. Once exhausted, the last registered response repeats.
+func (s *Server) On(method, path string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[routeKey(method, path)] = responses
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	key := routeKey(r.Method, r.URL.Path)
+	responses := s.routes[key]
+	var resp Response
+	switch {
+	case len(responses) == 0:
+		resp = Response{StatusCode: http.StatusNotFound, Body: map[string]string{"message": "no response scripted for " + key}}
+	case len(responses) == 1:
+		resp = responses[0]
+	default:
+		resp = responses[0]
+		s.routes[key] = responses[1:]
+	}
+	s.mu.Unlock()
+
+	if resp.NetworkError {
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+			}
+		}
+		return
+	}
+
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	statusCode := resp.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	if resp.Body != nil {
+		json.NewEncoder(w).Encode(resp.Body)
+	}
+}
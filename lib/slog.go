@@ -0,0 +1,14 @@
+package samplify
+
+import "log/slog"
+
+// SlogLogger adapts a *slog.Logger to the Logger interface, so it can be plugged in
+// via ClientOptions.Logger.
+type SlogLogger struct {
+	Logger *slog.Logger
+}
+
+func (l SlogLogger) Debug(msg string, keyvals ...interface{}) { l.Logger.Debug(msg, keyvals...) }
+func (l SlogLogger) Info(msg string, keyvals ...interface{})  { l.Logger.Info(msg, keyvals...) }
+func (l SlogLogger) Warn(msg string, keyvals ...interface{})  { l.Logger.Warn(msg, keyvals...) }
+func (l SlogLogger) Error(msg string, keyvals ...interface{}) { l.Logger.Error(msg, keyvals...) }
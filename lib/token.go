@@ -0,0 +1,75 @@
+package samplify
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// TokenSource supplies access tokens for the Client. The default implementation
+// performs a password-grant request against Options.AuthURL using Credentials, but
+// callers can provide their own (e.g. a shared cache backed by Redis) via
+// ClientOptions.TokenSource.
+type TokenSource interface {
+	Token(ctx context.Context) (TokenResponse, error)
+}
+
+// passwordTokenSource is the default TokenSource, acquiring tokens via the
+// password-grant credentials stored on the Client.
+type passwordTokenSource struct {
+	client *Client
+}
+
+func (p *passwordTokenSource) Token(ctx context.Context) (TokenResponse, error) {
+	p.client.logger().Info("samplify: acquiring access token", "clientID", p.client.Credentials.ClientID)
+	t := time.Now()
+	ar, err := sendRequest(ctx, p.client.httpClient(), p.client.Options.AuthURL, "POST", "", "", p.client.Credentials)
+	if err != nil {
+		p.client.logger().Error("samplify: failed to acquire access token", "clientID", p.client.Credentials.ClientID, "error", err)
+		return TokenResponse{}, err
+	}
+	var token TokenResponse
+	if err := json.Unmarshal(ar.Body, &token); err != nil {
+		return TokenResponse{}, err
+	}
+	token.Acquired = &t
+	p.client.logger().Info("samplify: acquired access token", "clientID", p.client.Credentials.ClientID, "accessToken", redactToken(token.AccessToken))
+	return token, nil
+}
+
+func (c *Client) tokenSource() TokenSource {
+	if c.Options.TokenSource != nil {
+		return c.Options.TokenSource
+	}
+	return &passwordTokenSource{client: c}
+}
+
+// currentAuth returns the cached token under a read lock.
+func (c *Client) currentAuth() TokenResponse {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.Auth
+}
+
+// authExpired reports whether the cached token needs to be refreshed.
+func (c *Client) authExpired() bool {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.Auth.AccessTokenExpired()
+}
+
+// refreshToken acquires a new token via c.tokenSource(), coalescing concurrent
+// refreshes into a single in-flight request via singleflight.
+func (c *Client) refreshToken(ctx context.Context) error {
+	_, err, _ := c.authGroup.Do("token", func() (interface{}, error) {
+		token, err := c.tokenSource().Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.authMu.Lock()
+		c.Auth = token
+		c.authMu.Unlock()
+		return token, nil
+	})
+	return err
+}
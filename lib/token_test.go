@@ -0,0 +1,65 @@
+package samplify
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingTokenSource struct {
+	calls int32
+}
+
+func (s *countingTokenSource) Token(ctx context.Context) (TokenResponse, error) {
+	atomic.AddInt32(&s.calls, 1)
+	time.Sleep(50 * time.Millisecond)
+	return TokenResponse{AccessToken: "tok", ExpiresIn: 3600}, nil
+}
+
+func TestRefreshTokenCoalescesConcurrentCallers(t *testing.T) {
+	src := &countingTokenSource{}
+	c := &Client{Options: ClientOptions{TokenSource: src}}
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.refreshToken(context.Background()); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("refreshToken: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&src.calls); got != 1 {
+		t.Errorf("TokenSource.Token called %d times for %d concurrent callers, want 1", got, n)
+	}
+	if got := c.currentAuth().AccessToken; got != "tok" {
+		t.Errorf("currentAuth().AccessToken = %q, want %q", got, "tok")
+	}
+}
+
+func TestRefreshTokenRefreshesAgainAfterCompletion(t *testing.T) {
+	src := &countingTokenSource{}
+	c := &Client{Options: ClientOptions{TokenSource: src}}
+
+	if err := c.refreshToken(context.Background()); err != nil {
+		t.Fatalf("first refreshToken: %v", err)
+	}
+	if err := c.refreshToken(context.Background()); err != nil {
+		t.Fatalf("second refreshToken: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&src.calls); got != 2 {
+		t.Errorf("TokenSource.Token called %d times across two sequential refreshes, want 2", got)
+	}
+}
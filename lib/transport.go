@@ -0,0 +1,77 @@
+package samplify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// APIResponse is the raw result of a single HTTP round trip against the Samplify API.
+type APIResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ErrorResponse is returned whenever the Samplify API responds with a non-2xx status
+// code. It implements the error interface so it can be returned directly.
+type ErrorResponse struct {
+	HTTPCode int      `json:"-"`
+	Code     string   `json:"code,omitempty"`
+	Message  string   `json:"message"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+func (e *ErrorResponse) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("samplify: request failed with status %d: %s", e.HTTPCode, e.Message)
+	}
+	return fmt.Sprintf("samplify: request failed with status %d", e.HTTPCode)
+}
+
+// sendRequest issues a single HTTP request to baseURL+path using httpClient and
+// returns the parsed APIResponse. Non-2xx responses are returned as an *ErrorResponse
+// alongside the raw APIResponse, so callers can inspect the body even on failure.
+func sendRequest(ctx context.Context, httpClient *http.Client, baseURL, method, path, accessToken string, body interface{}) (*APIResponse, error) {
+	var reqBody *bytes.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ar := &APIResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errResp := &ErrorResponse{HTTPCode: resp.StatusCode}
+		json.Unmarshal(respBody, errResp)
+		return ar, errResp
+	}
+	return ar, nil
+}
@@ -0,0 +1,44 @@
+// Package webhook provides an http.Handler that verifies and dispatches
+// asynchronous Samplify webhook deliveries, as an alternative to polling
+// GetFeasibility and GetProjectReport.
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType identifies the kind of event carried by an Envelope.
+type EventType string
+
+const (
+	EventFeasibilityReady     EventType = "FEASIBILITY_READY"
+	EventLineItemStateChanged EventType = "LINE_ITEM_STATE_CHANGED"
+	EventProjectClosed        EventType = "PROJECT_CLOSED"
+)
+
+// Envelope is the outer JSON structure of every webhook delivery. Data holds the
+// event-specific payload, keyed off Type.
+type Envelope struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// FeasibilityReadyEvent is delivered when Envelope.Type == EventFeasibilityReady.
+type FeasibilityReadyEvent struct {
+	ExtProjectID string `json:"extProjectId"`
+}
+
+// LineItemStateChangedEvent is delivered when Envelope.Type == EventLineItemStateChanged.
+type LineItemStateChangedEvent struct {
+	ExtProjectID  string `json:"extProjectId"`
+	ExtLineItemID string `json:"extLineItemId"`
+	State         string `json:"state"`
+}
+
+// ProjectClosedEvent is delivered when Envelope.Type == EventProjectClosed.
+type ProjectClosedEvent struct {
+	ExtProjectID string `json:"extProjectId"`
+}
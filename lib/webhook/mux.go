@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	signatureHeader = "X-Samplify-Signature"
+	defaultMaxSkew  = 5 * time.Minute
+)
+
+// IdempotencyCache deduplicates webhook deliveries by Envelope.ID. Seen reports
+// whether id has already been processed, recording it as seen otherwise.
+type IdempotencyCache interface {
+	Seen(ctx context.Context, id string) (bool, error)
+}
+
+// Mux is an http.Handler that verifies a webhook delivery's HMAC-SHA256 signature,
+// rejects stale or replayed deliveries, and dispatches the decoded event to the
+// handler registered for its EventType.
+type Mux struct {
+	// Secret is the shared secret used to verify the X-Samplify-Signature header.
+	Secret []byte
+
+	// MaxClockSkew bounds how far a delivery's Envelope.Timestamp may drift from
+	// now before it is rejected as a potential replay. Defaults to 5 minutes.
+	MaxClockSkew time.Duration
+
+	// IdempotencyCache, if set, is consulted to reject deliveries whose
+	// Envelope.ID has already been processed.
+	IdempotencyCache IdempotencyCache
+
+	handlers map[EventType]func(context.Context, json.RawMessage) error
+}
+
+// NewMux returns a Mux that verifies deliveries against secret.
+func NewMux(secret []byte) *Mux {
+	return &Mux{
+		Secret:   secret,
+		handlers: make(map[EventType]func(context.Context, json.RawMessage) error),
+	}
+}
+
+// HandleFeasibilityReady registers fn to be called for FeasibilityReadyEvent deliveries.
+func (m *Mux) HandleFeasibilityReady(fn func(ctx context.Context, event FeasibilityReadyEvent) error) {
+	m.handlers[EventFeasibilityReady] = func(ctx context.Context, raw json.RawMessage) error {
+		var event FeasibilityReadyEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return err
+		}
+		return fn(ctx, event)
+	}
+}
+
+// HandleLineItemStateChanged registers fn to be called for LineItemStateChangedEvent deliveries.
+func (m *Mux) HandleLineItemStateChanged(fn func(ctx context.Context, event LineItemStateChangedEvent) error) {
+	m.handlers[EventLineItemStateChanged] = func(ctx context.Context, raw json.RawMessage) error {
+		var event LineItemStateChangedEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return err
+		}
+		return fn(ctx, event)
+	}
+}
+
+// HandleProjectClosed registers fn to be called for ProjectClosedEvent deliveries.
+func (m *Mux) HandleProjectClosed(fn func(ctx context.Context, event ProjectClosedEvent) error) {
+	m.handlers[EventProjectClosed] = func(ctx context.Context, raw json.RawMessage) error {
+		var event ProjectClosedEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return err
+		}
+		return fn(ctx, event)
+	}
+}
+
+// ServeHTTP verifies the delivery's signature and freshness, then dispatches it to
+// the handler registered for its EventType. Deliveries with no registered handler,
+// or that the IdempotencyCache reports as already seen, are acknowledged with 200
+// and otherwise ignored.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if !m.verifySignature(body, r.Header.Get(signatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if !m.withinClockSkew(env.Timestamp) {
+		http.Error(w, "stale or replayed delivery", http.StatusBadRequest)
+		return
+	}
+
+	if m.IdempotencyCache != nil {
+		seen, err := m.IdempotencyCache.Seen(r.Context(), env.ID)
+		if err != nil {
+			http.Error(w, "idempotency check failed", http.StatusInternalServerError)
+			return
+		}
+		if seen {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	handler, ok := m.handlers[env.Type]
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := handler(r.Context(), env.Data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *Mux) verifySignature(body []byte, signature string) bool {
+	if signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, m.Secret)
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func (m *Mux) withinClockSkew(ts time.Time) bool {
+	maxSkew := m.MaxClockSkew
+	if maxSkew <= 0 {
+		maxSkew = defaultMaxSkew
+	}
+	d := time.Since(ts)
+	if d < 0 {
+		d = -d
+	}
+	return d <= maxSkew
+}
@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("shared-secret")
+
+func sign(body []byte, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newRequest(t *testing.T, env Envelope, secret []byte) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/samplify", bytes.NewReader(body))
+	if secret != nil {
+		req.Header.Set(signatureHeader, sign(body, secret))
+	}
+	return req
+}
+
+func TestMuxDispatchesToRegisteredHandler(t *testing.T) {
+	m := NewMux(testSecret)
+
+	var got FeasibilityReadyEvent
+	called := false
+	m.HandleFeasibilityReady(func(ctx context.Context, event FeasibilityReadyEvent) error {
+		called = true
+		got = event
+		return nil
+	})
+
+	data, _ := json.Marshal(FeasibilityReadyEvent{ExtProjectID: "proj-1"})
+	env := Envelope{ID: "evt-1", Type: EventFeasibilityReady, Timestamp: time.Now(), Data: data}
+	req := newRequest(t, env, testSecret)
+	rec := httptest.NewRecorder()
+
+	m.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !called {
+		t.Fatal("registered handler was not called")
+	}
+	if got.ExtProjectID != "proj-1" {
+		t.Errorf("event.ExtProjectID = %q, want %q", got.ExtProjectID, "proj-1")
+	}
+}
+
+func TestMuxRejectsInvalidSignature(t *testing.T) {
+	m := NewMux(testSecret)
+	called := false
+	m.HandleProjectClosed(func(ctx context.Context, event ProjectClosedEvent) error {
+		called = true
+		return nil
+	})
+
+	env := Envelope{ID: "evt-2", Type: EventProjectClosed, Timestamp: time.Now(), Data: []byte(`{}`)}
+	req := newRequest(t, env, []byte("wrong-secret"))
+	rec := httptest.NewRecorder()
+
+	m.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if called {
+		t.Fatal("handler was called despite an invalid signature")
+	}
+}
+
+func TestMuxRejectsMissingSignature(t *testing.T) {
+	m := NewMux(testSecret)
+	env := Envelope{ID: "evt-3", Type: EventProjectClosed, Timestamp: time.Now(), Data: []byte(`{}`)}
+	req := newRequest(t, env, nil)
+	rec := httptest.NewRecorder()
+
+	m.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMuxRejectsStaleDelivery(t *testing.T) {
+	m := NewMux(testSecret)
+	m.MaxClockSkew = 1 * time.Minute
+
+	called := false
+	m.HandleProjectClosed(func(ctx context.Context, event ProjectClosedEvent) error {
+		called = true
+		return nil
+	})
+
+	env := Envelope{ID: "evt-4", Type: EventProjectClosed, Timestamp: time.Now().Add(-10 * time.Minute), Data: []byte(`{}`)}
+	req := newRequest(t, env, testSecret)
+	rec := httptest.NewRecorder()
+
+	m.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if called {
+		t.Fatal("handler was called for a stale/replayed delivery")
+	}
+}
+
+type fakeIdempotencyCache struct {
+	seen map[string]bool
+}
+
+func (c *fakeIdempotencyCache) Seen(ctx context.Context, id string) (bool, error) {
+	wasSeen := c.seen[id]
+	c.seen[id] = true
+	return wasSeen, nil
+}
+
+func TestMuxIdempotencyCacheRejectsDuplicateDelivery(t *testing.T) {
+	cache := &fakeIdempotencyCache{seen: map[string]bool{}}
+	m := NewMux(testSecret)
+	m.IdempotencyCache = cache
+
+	calls := 0
+	m.HandleProjectClosed(func(ctx context.Context, event ProjectClosedEvent) error {
+		calls++
+		return nil
+	})
+
+	env := Envelope{ID: "evt-5", Type: EventProjectClosed, Timestamp: time.Now(), Data: []byte(`{}`)}
+
+	for i := 0; i < 2; i++ {
+		req := newRequest(t, env, testSecret)
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("delivery %d: status = %d, want %d", i, rec.Code, http.StatusOK)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("handler called %d times for a duplicate delivery, want 1", calls)
+	}
+}
+
+func TestMuxAcknowledgesUnregisteredEventType(t *testing.T) {
+	m := NewMux(testSecret)
+	env := Envelope{ID: "evt-6", Type: EventLineItemStateChanged, Timestamp: time.Now(), Data: []byte(`{}`)}
+	req := newRequest(t, env, testSecret)
+	rec := httptest.NewRecorder()
+
+	m.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}